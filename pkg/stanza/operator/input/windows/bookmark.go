@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Persister is the subset of the stanza persister interface the
+// BookmarkManager needs to durably store a channel's bookmark across
+// collector restarts.
+type Persister interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// bookmarkPersisterKeyPrefix namespaces bookmark entries within the
+// operator's persister so they don't collide with other state it stores.
+const bookmarkPersisterKeyPrefix = "windows_bookmark_"
+
+// errStaleBookmark is returned by callers translating the Win32
+// ERROR_EVT_QUERY_RESULT_STALE code, which EvtSubscribe surfaces when a
+// persisted bookmark's record has been rotated out of the channel's log.
+var errStaleBookmark = fmt.Errorf("bookmark record has been rotated out of the channel log")
+
+// BookmarkManager batches and persists a single channel's RenderedBookmark
+// XML (as produced by EvtCreateBookmark/EvtUpdateBookmark) so that a
+// restarted collector can resume with EvtSubscribeStartAfterBookmark instead
+// of re-reading or skipping events. Callers are expected to invoke Update
+// after each successful downstream emit, and Flush during Stop.
+type BookmarkManager struct {
+	channel         string
+	persister       Persister
+	flushEvents     int
+	flushInterval   time.Duration
+	maxReplayEvents int
+
+	mu                sync.Mutex
+	pending           string
+	eventsSinceFlush  int
+	lastFlush         time.Time
+	lastFlushDuration time.Duration
+	staleFallbacks    int
+}
+
+// NewBookmarkManager creates a BookmarkManager for channel. flushEvents and
+// flushInterval mirror the operator's bookmark batching config (a bookmark is
+// flushed once either threshold is reached); a non-positive value disables
+// that threshold. maxReplayEvents is the max_replay_events cap on events
+// redelivered after a stale-bookmark fallback; the manager only stores it and
+// returns it from MaxReplayEvents, and does not count or limit replayed events.
+func NewBookmarkManager(channel string, persister Persister, flushEvents int, flushInterval time.Duration, maxReplayEvents int) *BookmarkManager {
+	return &BookmarkManager{
+		channel:         channel,
+		persister:       persister,
+		flushEvents:     flushEvents,
+		flushInterval:   flushInterval,
+		maxReplayEvents: maxReplayEvents,
+		// Seed lastFlush so the flush_interval threshold is measured from
+		// creation, not from a zero time that would make the very first
+		// Update due immediately regardless of the configured thresholds.
+		lastFlush: time.Now(),
+	}
+}
+
+// persisterKey is the key this manager's bookmark is stored under.
+func (b *BookmarkManager) persisterKey() string {
+	return bookmarkPersisterKeyPrefix + b.channel
+}
+
+// Load returns the persisted RenderedBookmark XML for this manager's
+// channel, and false if none has been persisted yet, so the caller can fall
+// back to its configured start_at.
+func (b *BookmarkManager) Load(ctx context.Context) (string, bool, error) {
+	data, err := b.persister.Get(ctx, b.persisterKey())
+	if err != nil {
+		return "", false, fmt.Errorf("load bookmark for channel %q: %w", b.channel, err)
+	}
+	if len(data) == 0 {
+		return "", false, nil
+	}
+	return string(data), true, nil
+}
+
+// Update records renderedBookmark as the latest bookmark for an
+// already-emitted event, flushing it to the persister immediately if the
+// configured flush_events or flush_interval threshold has been reached. If
+// neither threshold is configured, every call flushes.
+func (b *BookmarkManager) Update(ctx context.Context, renderedBookmark string) error {
+	b.mu.Lock()
+	b.pending = renderedBookmark
+	b.eventsSinceFlush++
+
+	dueByCount := b.flushEvents > 0 && b.eventsSinceFlush >= b.flushEvents
+	dueByTime := b.flushInterval > 0 && time.Since(b.lastFlush) >= b.flushInterval
+	// If neither threshold is configured, flush on every update.
+	due := dueByCount || dueByTime || (b.flushEvents <= 0 && b.flushInterval <= 0)
+	b.mu.Unlock()
+
+	if !due {
+		return nil
+	}
+
+	return b.Flush(ctx)
+}
+
+// Flush unconditionally persists the latest pending bookmark, if any. It
+// should also be called during the operator's Stop so the last events of a
+// run aren't re-delivered on the next Start.
+func (b *BookmarkManager) Flush(ctx context.Context) error {
+	b.mu.Lock()
+	pending := b.pending
+	b.mu.Unlock()
+
+	if pending == "" {
+		return nil
+	}
+
+	start := time.Now()
+	err := b.persister.Set(ctx, b.persisterKey(), []byte(pending))
+
+	b.mu.Lock()
+	b.lastFlush = time.Now()
+	b.lastFlushDuration = b.lastFlush.Sub(start)
+	b.eventsSinceFlush = 0
+	b.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("flush bookmark for channel %q: %w", b.channel, err)
+	}
+	return nil
+}
+
+// RecordStaleFallback should be invoked whenever EvtSubscribe reports
+// ERROR_EVT_QUERY_RESULT_STALE for this manager's bookmark, so operators can
+// alarm on the returned StaleFallbackCount.
+func (b *BookmarkManager) RecordStaleFallback() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.staleFallbacks++
+}
+
+// MaxReplayEvents returns the configured max_replay_events safety cap.
+func (b *BookmarkManager) MaxReplayEvents() int {
+	return b.maxReplayEvents
+}
+
+// BookmarkAge reports how long it has been since the bookmark was last
+// flushed to the persister (or, before the first flush, since the manager was
+// created), for exposing a bookmark-age metric.
+func (b *BookmarkManager) BookmarkAge() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.lastFlush.IsZero() {
+		return 0
+	}
+	return time.Since(b.lastFlush)
+}
+
+// LastFlushDuration reports how long the most recent persister.Set call took.
+func (b *BookmarkManager) LastFlushDuration() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastFlushDuration
+}
+
+// StaleFallbackCount reports how many times RecordStaleFallback has been
+// called for this manager's channel.
+func (b *BookmarkManager) StaleFallbackCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.staleFallbacks
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakePersister struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func newFakePersister() *fakePersister {
+	return &fakePersister{data: make(map[string][]byte)}
+}
+
+func (f *fakePersister) Get(_ context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.data[key], nil
+}
+
+func (f *fakePersister) Set(_ context.Context, key string, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data[key] = value
+	return nil
+}
+
+func TestBookmarkManagerLoadMissingReturnsFalse(t *testing.T) {
+	manager := NewBookmarkManager("Application", newFakePersister(), 5, time.Minute, 1000)
+
+	_, found, err := manager.Load(context.Background())
+	require.NoError(t, err)
+	require.False(t, found)
+}
+
+func TestBookmarkManagerBatchesByEventCount(t *testing.T) {
+	persister := newFakePersister()
+	manager := NewBookmarkManager("Application", persister, 3, time.Hour, 1000)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Update(ctx, "<Bookmark1/>"))
+	require.NoError(t, manager.Update(ctx, "<Bookmark2/>"))
+
+	_, found, err := manager.Load(ctx)
+	require.NoError(t, err)
+	require.False(t, found, "an update below flush_events should not have flushed yet")
+
+	require.NoError(t, manager.Update(ctx, "<Bookmark3/>"))
+
+	bookmark, found, err := manager.Load(ctx)
+	require.NoError(t, err)
+	require.True(t, found, "the third update should have crossed the flush_events threshold")
+	require.Equal(t, "<Bookmark3/>", bookmark)
+}
+
+func TestBookmarkManagerFlushOnStop(t *testing.T) {
+	persister := newFakePersister()
+	manager := NewBookmarkManager("Application", persister, 1000, time.Hour, 1000)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Update(ctx, "<Bookmark1/>"))
+
+	_, found, err := manager.Load(ctx)
+	require.NoError(t, err)
+	require.False(t, found, "below both thresholds, Update alone should not flush")
+
+	require.NoError(t, manager.Flush(ctx))
+
+	bookmark, found, err := manager.Load(ctx)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "<Bookmark1/>", bookmark)
+}
+
+func TestBookmarkManagerFlushesImmediatelyWithoutThresholds(t *testing.T) {
+	persister := newFakePersister()
+	manager := NewBookmarkManager("Application", persister, 0, 0, 1000)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Update(ctx, "<Bookmark1/>"))
+
+	bookmark, found, err := manager.Load(ctx)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "<Bookmark1/>", bookmark)
+}
+
+func TestBookmarkManagerRecordStaleFallback(t *testing.T) {
+	manager := NewBookmarkManager("Application", newFakePersister(), 5, time.Minute, 2000)
+
+	require.Equal(t, 0, manager.StaleFallbackCount())
+	manager.RecordStaleFallback()
+	manager.RecordStaleFallback()
+	require.Equal(t, 2, manager.StaleFallbackCount())
+	require.Equal(t, 2000, manager.MaxReplayEvents())
+}
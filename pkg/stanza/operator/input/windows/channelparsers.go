@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
+
+import (
+	"container/list"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ChannelParserFunc extracts a message and a structured details map from the
+// rendered message of an event on a given channel.
+type ChannelParserFunc func(message string) (string, map[string]interface{})
+
+type channelParserEntry struct {
+	pattern string
+	parse   ChannelParserFunc
+}
+
+var (
+	channelParsersMu sync.RWMutex
+	channelParsers   []channelParserEntry
+)
+
+// RegisterChannelParser registers fn to run against events whose Channel
+// matches pattern. pattern supports the same glob syntax as path.Match (e.g.
+// "Microsoft-Windows-AppLocker/*"), so a single registration can cover a
+// provider's family of channels. Re-registering the same pattern replaces the
+// previously registered parser.
+func RegisterChannelParser(pattern string, fn ChannelParserFunc) {
+	channelParsersMu.Lock()
+	defer channelParsersMu.Unlock()
+
+	for i, entry := range channelParsers {
+		if entry.pattern == pattern {
+			channelParsers[i].parse = fn
+			return
+		}
+	}
+
+	channelParsers = append(channelParsers, channelParserEntry{pattern: pattern, parse: fn})
+}
+
+// lookupChannelParser returns the parser registered for channel, skipping any
+// pattern present in disabled. Patterns are matched in registration order and
+// the first match wins, so a pattern registered afterwards is not consulted for
+// channels an earlier pattern already matches; a built-in parser registered by
+// this package's init is overridden by registering the same pattern again.
+func lookupChannelParser(channel string, disabled []string) (ChannelParserFunc, bool) {
+	channelParsersMu.RLock()
+	defer channelParsersMu.RUnlock()
+
+	for _, entry := range channelParsers {
+		if isChannelParserDisabled(entry.pattern, disabled) {
+			continue
+		}
+		if matched, _ := path.Match(entry.pattern, channel); matched {
+			return entry.parse, true
+		}
+	}
+
+	return nil, false
+}
+
+func isChannelParserDisabled(pattern string, disabled []string) bool {
+	for _, d := range disabled {
+		if d == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	RegisterChannelParser("Security", parseSecurity)
+	RegisterChannelParser("Microsoft-Windows-Sysmon/Operational", parseSysmon)
+	// The legacy "Windows PowerShell" channel uses its own message formats
+	// for its event IDs; ScriptBlockId:-shaped ScriptBlockText events (4104)
+	// are only emitted on Microsoft-Windows-PowerShell/Operational.
+	RegisterChannelParser("Microsoft-Windows-PowerShell/Operational", parsePowerShellScriptBlock)
+}
+
+// sysmonFieldPattern matches the "Name: Value" lines Sysmon emits in its
+// rendered message, one per line, after an introductory sentence. The value
+// is required to be non-empty so the introductory sentence itself, which
+// Sysmon also terminates with a bare colon, isn't mistaken for a field.
+var sysmonFieldPattern = regexp.MustCompile(`^([A-Za-z0-9 ]+):\s+(.+)$`)
+
+// parseSysmon extracts Sysmon's "Name: Value" fields into a details map,
+// mirroring the shape parseSecurity produces. Any leading lines that don't
+// match the field pattern (Sysmon's descriptive sentence) are kept as the
+// returned message.
+func parseSysmon(message string) (string, map[string]interface{}) {
+	lines := strings.Split(message, "\r\n")
+	details := make(map[string]interface{})
+	var messageLines []string
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if groups := sysmonFieldPattern.FindStringSubmatch(trimmed); groups != nil {
+			details[strings.TrimSpace(groups[1])] = strings.TrimSpace(groups[2])
+			continue
+		}
+
+		messageLines = append(messageLines, trimmed)
+	}
+
+	if len(details) == 0 {
+		return message, nil
+	}
+
+	return strings.Join(messageLines, " "), details
+}
+
+// scriptBlockFragment holds the fragments received so far of a multi-part
+// PowerShell ScriptBlockText event, keyed by its ScriptBlockId.
+type scriptBlockFragment struct {
+	total    int
+	received map[int]string
+	expires  time.Time
+}
+
+// scriptBlockCacheCapacity and scriptBlockFragmentTTL bound scriptBlockCache
+// so a ScriptBlockId whose final MessageTotal fragment never arrives (process
+// restart, dropped event) doesn't leak for the life of the process.
+const (
+	scriptBlockCacheCapacity = 4096
+	scriptBlockFragmentTTL   = 5 * time.Minute
+)
+
+var (
+	scriptBlockMu    sync.Mutex
+	scriptBlockOrder = list.New()
+	scriptBlockElems = make(map[string]*list.Element)
+	scriptBlockCache = make(map[string]*scriptBlockFragment)
+)
+
+// scriptBlockEvict removes expired or excess entries from the front of
+// scriptBlockOrder (the oldest insertions). Callers must hold scriptBlockMu.
+func scriptBlockEvict(now time.Time) {
+	for scriptBlockOrder.Len() > 0 {
+		oldest := scriptBlockOrder.Front()
+		id := oldest.Value.(string)
+
+		if fragment := scriptBlockCache[id]; fragment != nil && now.Before(fragment.expires) &&
+			scriptBlockOrder.Len() <= scriptBlockCacheCapacity {
+			return
+		}
+
+		scriptBlockOrder.Remove(oldest)
+		delete(scriptBlockElems, id)
+		delete(scriptBlockCache, id)
+	}
+}
+
+var (
+	scriptBlockIDPattern   = regexp.MustCompile(`ScriptBlockId:\s*([0-9a-fA-F-]+)`)
+	scriptBlockPathPattern = regexp.MustCompile(`Path:\s*(.*)`)
+	messageNumberPattern   = regexp.MustCompile(`MessageNumber:\s*(\d+)`)
+	messageTotalPattern    = regexp.MustCompile(`MessageTotal:\s*(\d+)`)
+
+	// scriptBlockHeaderPattern matches the "Creating Scriptblock text (N of
+	// M):" sentence PowerShell renders before the script body.
+	scriptBlockHeaderPattern = regexp.MustCompile(`^(Creating Scriptblock text \(\d+ of \d+\):)\r?\n`)
+	// scriptBlockFooterPattern matches the start of the ScriptBlockId: line
+	// that PowerShell renders after the script body.
+	scriptBlockFooterPattern = regexp.MustCompile(`(?m)^ScriptBlockId:`)
+)
+
+// splitScriptBlockMessage separates a rendered PowerShell ScriptBlockText
+// message into its header sentence and the script-body fragment it carries,
+// stripping the header and the ScriptBlockId/Path/MessageNumber/MessageTotal
+// footer rendering appends after the body.
+func splitScriptBlockMessage(message string) (header, body string) {
+	body = message
+	if loc := scriptBlockHeaderPattern.FindStringSubmatchIndex(message); loc != nil {
+		header = message[loc[2]:loc[3]]
+		body = message[loc[1]:]
+	} else {
+		header = message
+	}
+
+	if loc := scriptBlockFooterPattern.FindStringIndex(body); loc != nil {
+		body = body[:loc[0]]
+	}
+
+	return header, body
+}
+
+// parsePowerShellScriptBlock extracts ScriptBlockId and Path from a
+// PowerShell ScriptBlockText event (event ID 4104) and reassembles the full
+// script text across the MessageNumber/MessageTotal fragments PowerShell
+// splits large scripts into. The reassembled text is only returned once the
+// final fragment for a ScriptBlockId has been seen; until then, details
+// reports the fragment's own body. The returned message is the header
+// sentence, mirroring the shape parseSecurity/parseSysmon produce.
+func parsePowerShellScriptBlock(message string) (string, map[string]interface{}) {
+	idMatch := scriptBlockIDPattern.FindStringSubmatch(message)
+	if idMatch == nil {
+		return message, nil
+	}
+	scriptBlockID := idMatch[1]
+
+	header, body := splitScriptBlockMessage(message)
+
+	details := map[string]interface{}{"script_block_id": scriptBlockID}
+
+	if pathMatch := scriptBlockPathPattern.FindStringSubmatch(message); pathMatch != nil {
+		details["path"] = strings.TrimSpace(pathMatch[1])
+	}
+
+	number, total := 1, 1
+	if m := messageNumberPattern.FindStringSubmatch(message); m != nil {
+		number, _ = strconv.Atoi(m[1])
+	}
+	if m := messageTotalPattern.FindStringSubmatch(message); m != nil {
+		total, _ = strconv.Atoi(m[1])
+	}
+
+	if total <= 1 {
+		details["script_text"] = strings.TrimRight(body, "\r\n")
+		return header, details
+	}
+
+	now := time.Now()
+
+	scriptBlockMu.Lock()
+	scriptBlockEvict(now)
+
+	fragment, ok := scriptBlockCache[scriptBlockID]
+	if !ok {
+		fragment = &scriptBlockFragment{total: total, received: make(map[int]string), expires: now.Add(scriptBlockFragmentTTL)}
+		scriptBlockCache[scriptBlockID] = fragment
+		scriptBlockElems[scriptBlockID] = scriptBlockOrder.PushBack(scriptBlockID)
+	}
+	// The raw (untrimmed) body is kept so each fragment's own line break is
+	// preserved when fragments are joined below.
+	fragment.received[number] = body
+
+	if len(fragment.received) < fragment.total {
+		scriptBlockMu.Unlock()
+		details["script_text"] = strings.TrimRight(body, "\r\n")
+		return header, details
+	}
+
+	if elem, ok := scriptBlockElems[scriptBlockID]; ok {
+		scriptBlockOrder.Remove(elem)
+		delete(scriptBlockElems, scriptBlockID)
+	}
+	delete(scriptBlockCache, scriptBlockID)
+	scriptBlockMu.Unlock()
+
+	var script strings.Builder
+	for i := 1; i <= total; i++ {
+		script.WriteString(fragment.received[i])
+	}
+
+	details["script_text"] = strings.TrimRight(script.String(), "\r\n")
+	return header, details
+}
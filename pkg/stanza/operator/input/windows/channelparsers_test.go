@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupChannelParser(t *testing.T) {
+	parse, ok := lookupChannelParser("Security", nil)
+	require.True(t, ok)
+	message, details := parse("TargetUserName:\ttestuser")
+	require.Equal(t, "", message)
+	require.Equal(t, map[string]interface{}{"TargetUserName": "testuser"}, details)
+
+	_, ok = lookupChannelParser("Security", []string{"Security"})
+	require.False(t, ok, "expected a disabled pattern to be skipped")
+
+	parse, ok = lookupChannelParser("Microsoft-Windows-AppLocker/EXE and DLL", nil)
+	require.False(t, ok, "no built-in parser is registered for AppLocker")
+	require.Nil(t, parse)
+
+	_, ok = lookupChannelParser("Microsoft-Windows-Does-Not-Exist", nil)
+	require.False(t, ok)
+}
+
+func TestRegisterChannelParserGlob(t *testing.T) {
+	called := false
+	RegisterChannelParser("Microsoft-Windows-TaskScheduler/*", func(msg string) (string, map[string]interface{}) {
+		called = true
+		return msg, nil
+	})
+	t.Cleanup(func() {
+		channelParsersMu.Lock()
+		for i, entry := range channelParsers {
+			if entry.pattern == "Microsoft-Windows-TaskScheduler/*" {
+				channelParsers = append(channelParsers[:i], channelParsers[i+1:]...)
+				break
+			}
+		}
+		channelParsersMu.Unlock()
+	})
+
+	parse, ok := lookupChannelParser("Microsoft-Windows-TaskScheduler/Operational", nil)
+	require.True(t, ok)
+	_, _ = parse("anything")
+	require.True(t, called)
+}
+
+func TestParseSysmon(t *testing.T) {
+	message := "Network connection detected:\r\n" +
+		"RuleName: -\r\n" +
+		"UtcTime: 2026-07-26 10:00:00.000\r\n" +
+		"ProcessGuid: {11111111-2222-3333-4444-555555555555}\r\n" +
+		"Image: C:\\Windows\\System32\\svchost.exe\r\n"
+
+	gotMessage, details := parseSysmon(message)
+	require.Equal(t, "Network connection detected:", gotMessage)
+	require.Equal(t, map[string]interface{}{
+		"RuleName":    "-",
+		"UtcTime":     "2026-07-26 10:00:00.000",
+		"ProcessGuid": "{11111111-2222-3333-4444-555555555555}",
+		"Image":       "C:\\Windows\\System32\\svchost.exe",
+	}, details)
+}
+
+func TestParseSysmonWithoutFields(t *testing.T) {
+	message := "a plain message with no key value fields"
+	gotMessage, details := parseSysmon(message)
+	require.Equal(t, message, gotMessage)
+	require.Nil(t, details)
+}
+
+func TestParsePowerShellScriptBlockSingleFragment(t *testing.T) {
+	message := "Creating Scriptblock text (1 of 1):\r\n" +
+		"Get-Process\r\n" +
+		"\r\n" +
+		"ScriptBlockId: 5145904f-76b5-4274-b12e-6a8a5113b1b9\r\n" +
+		"Path: C:\\scripts\\get-process.ps1"
+
+	gotMessage, details := parsePowerShellScriptBlock(message)
+	require.Equal(t, "Creating Scriptblock text (1 of 1):", gotMessage, "message should be the header sentence, not the raw rendering")
+	require.Equal(t, "5145904f-76b5-4274-b12e-6a8a5113b1b9", details["script_block_id"])
+	require.Equal(t, "C:\\scripts\\get-process.ps1", details["path"])
+	require.Equal(t, "Get-Process", details["script_text"], "script_text should not contain the header sentence or the ScriptBlockId/Path footer")
+}
+
+func TestParsePowerShellScriptBlockReassemblesFragments(t *testing.T) {
+	const id = "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee"
+
+	first := "Creating Scriptblock text (1 of 2):\r\nfunction Foo {\r\n" +
+		"ScriptBlockId: " + id + "\r\n" +
+		"MessageNumber: 1\r\n" +
+		"MessageTotal: 2"
+	second := "Creating Scriptblock text (2 of 2):\r\n}\r\n" +
+		"ScriptBlockId: " + id + "\r\n" +
+		"MessageNumber: 2\r\n" +
+		"MessageTotal: 2"
+
+	firstMessage, firstDetails := parsePowerShellScriptBlock(first)
+	require.Equal(t, "Creating Scriptblock text (1 of 2):", firstMessage)
+	require.Equal(t, "function Foo {", firstDetails["script_text"], "partial fragments report their own body, without the header/footer")
+
+	secondMessage, secondDetails := parsePowerShellScriptBlock(second)
+	require.Equal(t, "Creating Scriptblock text (2 of 2):", secondMessage)
+	require.Equal(t, "function Foo {\r\n}", secondDetails["script_text"], "final fragment reassembles the clean script, preserving the line break between fragments")
+
+	scriptBlockMu.Lock()
+	_, stillCached := scriptBlockCache[id]
+	scriptBlockMu.Unlock()
+	require.False(t, stillCached, "completed script blocks should be evicted from the cache")
+}
+
+func TestParsePowerShellScriptBlockWithoutScriptBlockID(t *testing.T) {
+	message := "no script block id here"
+	gotMessage, details := parsePowerShellScriptBlock(message)
+	require.Equal(t, message, gotMessage)
+	require.Nil(t, details)
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// defaultFormatMessageCacheSize is used when the operator does not configure
+// format_message_cache_size.
+const defaultFormatMessageCacheSize = 1000
+
+// messageFormatter renders a message for an event using the provider's
+// metadata handle, as a fallback for when the subscription's rendering
+// session did not produce a message (typically because the provider's
+// message DLL is not registered for the requested locale).
+type messageFormatter interface {
+	// FormatMessage renders the message template for eventID from the
+	// provider identified by providerName/providerGUID in the given locale.
+	FormatMessage(providerName, providerGUID string, eventID uint32, locale string) (string, error)
+}
+
+// evtFormatMessage is the active messageFormatter. It defaults to
+// unsupportedFormatter, which always fails; a windows-specific file may point
+// it at a real EvtFormatMessage binding.
+var evtFormatMessage messageFormatter = unsupportedFormatter{}
+
+// unsupportedFormatter is used where EvtFormatMessage is unavailable.
+type unsupportedFormatter struct{}
+
+func (unsupportedFormatter) FormatMessage(_, _ string, _ uint32, _ string) (string, error) {
+	return "", fmt.Errorf("message formatting fallback is not supported on this platform")
+}
+
+// messageCacheKey identifies a compiled format string within the messageCache.
+type messageCacheKey struct {
+	providerGUID string
+	eventID      uint32
+	locale       string
+}
+
+// messageCache is a fixed-size, least-recently-used cache of resolved
+// messages, keyed by (Provider.GUID, EventID.ID, Locale), so that repeated
+// events from the same provider don't re-invoke EvtFormatMessage.
+type messageCache struct {
+	mutex    sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[messageCacheKey]*list.Element
+}
+
+type messageCacheEntry struct {
+	key     messageCacheKey
+	message string
+}
+
+// newMessageCache creates a messageCache holding up to capacity entries.
+// A non-positive capacity disables caching.
+func newMessageCache(capacity int) *messageCache {
+	return &messageCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[messageCacheKey]*list.Element),
+	}
+}
+
+func (c *messageCache) get(key messageCacheKey) (string, bool) {
+	if c.capacity <= 0 {
+		return "", false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+
+	c.ll.MoveToFront(elem)
+	return elem.Value.(*messageCacheEntry).message, true
+}
+
+func (c *messageCache) add(key messageCacheKey, message string) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*messageCacheEntry).message = message
+		return
+	}
+
+	elem := c.ll.PushFront(&messageCacheEntry{key: key, message: message})
+	c.items[key] = elem
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*messageCacheEntry).key)
+		}
+	}
+}
+
+// resolveMessage returns the rendered message for the event, falling back to
+// EvtFormatMessage against the provider's metadata handle when renderedMessage
+// is empty and locale is set; with an empty locale renderedMessage is returned
+// as is. Results are cached in cache keyed by (Provider.GUID, EventID.ID,
+// Locale). Formatting failures are swallowed, and renderedMessage (the empty
+// string) is returned, when suppressRenderErrors is true.
+func (e *EventXML) resolveMessage(renderedMessage string, locale string, cache *messageCache, suppressRenderErrors bool) (string, error) {
+	if renderedMessage != "" || locale == "" {
+		return renderedMessage, nil
+	}
+
+	key := messageCacheKey{providerGUID: e.Provider.GUID, eventID: e.EventID.ID, locale: locale}
+
+	if cache != nil {
+		if message, ok := cache.get(key); ok {
+			return message, nil
+		}
+	}
+
+	message, err := evtFormatMessage.FormatMessage(e.Provider.Name, e.Provider.GUID, e.EventID.ID, locale)
+	if err != nil {
+		if suppressRenderErrors {
+			return "", nil
+		}
+		return "", fmt.Errorf("format message for provider %q event %d: %w", e.Provider.Name, e.EventID.ID, err)
+	}
+
+	if cache != nil {
+		cache.add(key, message)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageCacheEviction(t *testing.T) {
+	cache := newMessageCache(2)
+
+	keyA := messageCacheKey{providerGUID: "guid-a", eventID: 1, locale: "en-US"}
+	keyB := messageCacheKey{providerGUID: "guid-b", eventID: 2, locale: "en-US"}
+	keyC := messageCacheKey{providerGUID: "guid-c", eventID: 3, locale: "en-US"}
+
+	cache.add(keyA, "message-a")
+	cache.add(keyB, "message-b")
+
+	// Touch A so B becomes the least recently used entry.
+	_, ok := cache.get(keyA)
+	require.True(t, ok)
+
+	cache.add(keyC, "message-c")
+
+	_, ok = cache.get(keyB)
+	require.False(t, ok, "expected least-recently-used entry to be evicted")
+
+	message, ok := cache.get(keyA)
+	require.True(t, ok)
+	require.Equal(t, "message-a", message)
+
+	message, ok = cache.get(keyC)
+	require.True(t, ok)
+	require.Equal(t, "message-c", message)
+}
+
+func TestMessageCacheDisabledWithNonPositiveCapacity(t *testing.T) {
+	cache := newMessageCache(0)
+	key := messageCacheKey{providerGUID: "guid-a", eventID: 1, locale: "en-US"}
+
+	cache.add(key, "message-a")
+	_, ok := cache.get(key)
+	require.False(t, ok)
+}
+
+type stubFormatter struct {
+	calls int
+}
+
+func (s *stubFormatter) FormatMessage(providerName, _ string, eventID uint32, _ string) (string, error) {
+	s.calls++
+	return fmt.Sprintf("%s-%d", providerName, eventID), nil
+}
+
+func TestResolveMessageFallsBackAndCaches(t *testing.T) {
+	formatter := &stubFormatter{}
+	previous := evtFormatMessage
+	evtFormatMessage = formatter
+	defer func() { evtFormatMessage = previous }()
+
+	e := &EventXML{Provider: Provider{Name: "Provider", GUID: "{guid}"}, EventID: EventID{ID: 7}}
+	cache := newMessageCache(10)
+
+	message, err := e.resolveMessage("", "en-US", cache, false)
+	require.NoError(t, err)
+	require.Equal(t, "Provider-7", message)
+	require.Equal(t, 1, formatter.calls)
+
+	message, err = e.resolveMessage("", "en-US", cache, false)
+	require.NoError(t, err)
+	require.Equal(t, "Provider-7", message)
+	require.Equal(t, 1, formatter.calls, "expected second lookup to hit the cache")
+}
+
+func TestResolveMessagePassesThroughNonEmptyMessage(t *testing.T) {
+	e := &EventXML{}
+	message, err := e.resolveMessage("already rendered", "en-US", nil, false)
+	require.NoError(t, err)
+	require.Equal(t, "already rendered", message)
+}
@@ -0,0 +1,42 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
+
+import (
+	"regexp"
+	"strings"
+)
+
+// securityFieldPattern matches the "Key:\t\tValue" lines rendered beneath a
+// Security-channel event's header sentence. The value is required to be
+// non-empty so a bare header sentence ending in ':' isn't mistaken for a field.
+var securityFieldPattern = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9 /]*):\s+(.+)$`)
+
+// parseSecurity extracts a Security event's "Key: Value" fields into a
+// details map. Any header lines that don't match are kept as the message.
+func parseSecurity(message string) (string, map[string]interface{}) {
+	lines := strings.Split(message, "\r\n")
+	details := make(map[string]interface{})
+	var messageLines []string
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		if groups := securityFieldPattern.FindStringSubmatch(trimmed); groups != nil {
+			details[strings.TrimSpace(groups[1])] = strings.TrimSpace(groups[2])
+			continue
+		}
+
+		messageLines = append(messageLines, trimmed)
+	}
+
+	if len(details) == 0 {
+		return message, nil
+	}
+
+	return strings.Join(messageLines, " "), details
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/windows"
+
+import "strconv"
+
+// resolveSIDName resolves a Windows security identifier to an account name.
+// By default it resolves no SID; a windows-specific file may point it at
+// LookupAccountSid.
+var resolveSIDName = func(sid string) (string, bool) {
+	return "", false
+}
+
+// parseSemConvAttributes builds host.*/user.*/process.*/thread.*/event.*
+// semantic-convention attributes from the event, merged into parseBody's
+// output when render_semconv is set.
+func (e *EventXML) parseSemConvAttributes() map[string]interface{} {
+	attrs := map[string]interface{}{
+		"event.name":   e.Provider.Name + "/" + strconv.FormatUint(uint64(e.EventID.ID), 10),
+		"event.domain": "windows",
+	}
+
+	if e.Computer != "" {
+		attrs["host.name"] = e.Computer
+	}
+
+	if e.Channel != "" {
+		attrs["event.channel"] = e.Channel
+	}
+
+	level := e.RenderedLevel
+	if level == "" {
+		level = e.Level
+	}
+	if level != "" {
+		attrs["event.level"] = level
+	}
+
+	task := e.RenderedTask
+	if task == "" {
+		task = e.Task
+	}
+	if task != "" {
+		attrs["event.task"] = task
+	}
+
+	opcode := e.RenderedOpcode
+	if opcode == "" {
+		opcode = e.Opcode
+	}
+	if opcode != "" {
+		attrs["event.opcode"] = opcode
+	}
+
+	keywords := e.RenderedKeywords
+	if keywords == nil {
+		keywords = e.Keywords
+	}
+	if len(keywords) > 0 {
+		attrs["event.keywords"] = keywords
+	}
+
+	if e.Security != nil && e.Security.UserID != "" {
+		attrs["user.id"] = e.Security.UserID
+		if name, ok := resolveSIDName(e.Security.UserID); ok {
+			attrs["user.name"] = name
+		}
+	}
+
+	if e.Execution != nil {
+		attrs["process.pid"] = e.Execution.ProcessID
+		attrs["thread.id"] = e.Execution.ThreadID
+	}
+
+	return attrs
+}
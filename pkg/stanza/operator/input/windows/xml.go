@@ -7,6 +7,9 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
@@ -77,9 +80,30 @@ func (e *EventXML) parseSeverity() entry.Severity {
 	}
 }
 
+// EventXMLRenderOptions bundles the windows input operator's config options
+// that affect how an EventXML is rendered into an entry body.
+type EventXMLRenderOptions struct {
+	// EventDataTyped: event_data_typed. Coerce EventData values to their
+	// provider-declared Go types instead of leaving them as strings.
+	EventDataTyped bool
+	// RenderSemConv: render_semconv. Also populate OpenTelemetry semantic
+	// convention attributes (event.*, host.*, user.*, process.*) in the body.
+	RenderSemConv bool
+	// Locale, MessageCache, SuppressRenderErrors back the EvtFormatMessage
+	// fallback on resolveMessage: locale, format_message_cache_size,
+	// suppress_render_errors.
+	Locale               string
+	MessageCache         *messageCache
+	SuppressRenderErrors bool
+	// DisabledChannelParsers: disable_channel_parsers. Channel patterns, as
+	// registered with RegisterChannelParser, to skip even if a parser is
+	// registered for them.
+	DisabledChannelParsers []string
+}
+
 // parseBody will parse a body from the event.
-func (e *EventXML) parseBody() map[string]interface{} {
-	message, details := e.parseMessage()
+func (e *EventXML) parseBody(opts EventXMLRenderOptions) map[string]interface{} {
+	message, details := e.parseMessage(opts)
 
 	level := e.RenderedLevel
 	if level == "" {
@@ -120,7 +144,7 @@ func (e *EventXML) parseBody() map[string]interface{} {
 		"task":        task,
 		"opcode":      opcode,
 		"keywords":    keywords,
-		"event_data":  parseEventData(e.EventData),
+		"event_data":  parseEventData(e.EventData, opts.EventDataTyped),
 	}
 
 	if len(details) > 0 {
@@ -141,35 +165,123 @@ func (e *EventXML) parseBody() map[string]interface{} {
 		body["user_data"] = e.UserData.asMap()
 	}
 
+	if opts.RenderSemConv {
+		for k, v := range e.parseSemConvAttributes() {
+			body[k] = v
+		}
+	}
+
 	return body
 }
 
-// parseMessage will attempt to parse a message into a message and details
-func (e *EventXML) parseMessage() (string, map[string]interface{}) {
-	switch e.Channel {
-	case "Security":
-		return parseSecurity(e.Message)
-	default:
-		return e.Message, nil
+// parseMessage will attempt to parse a message into a message and details.
+// When the rendered message is empty (commonly because the provider's
+// message DLL isn't available in the current rendering session, e.g. for
+// non-English locales), it first falls back to EvtFormatMessage against
+// opts.Locale, consulting and populating opts.MessageCache, before running
+// the channel-specific parser registered for e.Channel, if any and not
+// disabled via opts.DisabledChannelParsers.
+func (e *EventXML) parseMessage(opts EventXMLRenderOptions) (string, map[string]interface{}) {
+	message, err := e.resolveMessage(e.Message, opts.Locale, opts.MessageCache, opts.SuppressRenderErrors)
+	if err != nil {
+		message = e.Message
+	}
+
+	if parse, ok := lookupChannelParser(e.Channel, opts.DisabledChannelParsers); ok {
+		return parse(message)
 	}
+
+	return message, nil
 }
 
 // parse event data entries into a map[string]interface
 // where the key is the Name attribute, and value is the element value
 // entries without Name are ignored
+// if typed is true, the value is coerced according to the Type attribute
+// published by the provider's EventData schema, otherwise it is left as a string
 // see: https://learn.microsoft.com/en-us/windows/win32/wes/eventschema-datafieldtype-complextype
-func parseEventData(entries []EventDataEntry) map[string]interface{} {
+func parseEventData(entries []EventDataEntry, typed bool) map[string]interface{} {
 	outputMap := make(map[string]interface{}, len(entries))
 
 	for _, entry := range entries {
-		if entry.Name != "" {
+		if entry.Name == "" {
+			continue
+		}
+
+		if !typed {
 			outputMap[entry.Name] = entry.Value
+			continue
 		}
+
+		outputMap[entry.Name] = entry.parseTypedValue()
 	}
 
 	return outputMap
 }
 
+// parseTypedValue coerces the entry's raw string value according to its
+// provider-supplied Type attribute (e.g. "win:UInt32", "win:HexInt64", "win:SID").
+// Types that are not recognized, or that fail to parse, are returned as the raw string.
+func (d EventDataEntry) parseTypedValue() interface{} {
+	switch d.Type {
+	case "win:Byte", "win:UInt8", "win:UInt16", "win:UInt32", "win:UInt64", "win:Pointer":
+		if v, err := strconv.ParseUint(d.Value, 0, 64); err == nil {
+			return uintToBody(v)
+		}
+	case "win:Int8", "win:Int16", "win:Int32", "win:Int64":
+		if v, err := strconv.ParseInt(d.Value, 0, 64); err == nil {
+			return v
+		}
+	case "win:HexInt32", "win:HexInt64":
+		if v, err := strconv.ParseUint(strings.TrimPrefix(d.Value, "0x"), 16, 64); err == nil {
+			return uintToBody(v)
+		}
+	case "win:Boolean":
+		if v, err := strconv.ParseBool(d.Value); err == nil {
+			return v
+		}
+	case "win:FILETIME":
+		if v, err := parseFILETIME(d.Value); err == nil {
+			return v
+		}
+	case "win:SYSTEMTIME":
+		if v, err := time.Parse(time.RFC3339Nano, d.Value); err == nil {
+			return v
+		}
+	case "win:Binary":
+		return strings.ToLower(d.Value)
+	case "win:GUID", "win:SID", "win:String", "win:UnicodeString", "win:AnsiString":
+		return d.Value
+	}
+
+	return d.Value
+}
+
+// uintToBody converts v to the int64 the entry body will hold, since pdata
+// log bodies have no unsigned integer kind. Values above math.MaxInt64 are
+// rendered as their decimal string instead of wrapping into a negative int64.
+func uintToBody(v uint64) interface{} {
+	if v <= math.MaxInt64 {
+		return int64(v)
+	}
+	return strconv.FormatUint(v, 10)
+}
+
+// parseFILETIME parses a FILETIME value, which providers render either as a
+// raw 64-bit tick count (100ns intervals since 1601-01-01) or as an already
+// formatted timestamp, depending on the rendering session.
+func parseFILETIME(value string) (time.Time, error) {
+	if ticks, err := strconv.ParseUint(value, 0, 64); err == nil {
+		const filetimeToUnixOffsetTicks = 116444736000000000
+		if ticks < filetimeToUnixOffsetTicks {
+			return time.Time{}, fmt.Errorf("filetime value %d predates the unix epoch", ticks)
+		}
+		return time.Unix(0, int64(ticks-filetimeToUnixOffsetTicks)*100).UTC(), nil
+	}
+
+	return time.Parse(time.RFC3339Nano, value)
+}
+
 // unmarshalEventXML will unmarshal EventXML from xml bytes.
 func unmarshalEventXML(bytes []byte) (EventXML, error) {
 	var eventXML EventXML
@@ -198,7 +310,11 @@ type Provider struct {
 }
 
 type EventDataEntry struct {
-	Name  string `xml:"Name,attr"`
+	Name string `xml:"Name,attr"`
+	// Type is the provider-declared EventData schema type (e.g. "win:UInt32",
+	// "win:HexInt64", "win:SID"). It is only populated when the publisher's
+	// manifest is available, and is empty for most providers.
+	Type  string `xml:"Type,attr"`
 	Value string `xml:",chardata"`
 }
 
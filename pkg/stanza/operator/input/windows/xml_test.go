@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package windows
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDataEntryParseTypedValue(t *testing.T) {
+	cases := []struct {
+		name   string
+		entry  EventDataEntry
+		expect interface{}
+	}{
+		{
+			name:   "decimal uint32",
+			entry:  EventDataEntry{Type: "win:UInt32", Value: "4096"},
+			expect: int64(4096),
+		},
+		{
+			name:   "hex uint64 pointer",
+			entry:  EventDataEntry{Type: "win:Pointer", Value: "0xFFFF"},
+			expect: int64(0xFFFF),
+		},
+		{
+			name:   "uint64 above int64 max is stringified",
+			entry:  EventDataEntry{Type: "win:UInt64", Value: "18446744073709551615"},
+			expect: "18446744073709551615",
+		},
+		{
+			name:   "hex int64",
+			entry:  EventDataEntry{Type: "win:HexInt64", Value: "0x2a"},
+			expect: int64(42),
+		},
+		{
+			name:   "signed int32",
+			entry:  EventDataEntry{Type: "win:Int32", Value: "-7"},
+			expect: int64(-7),
+		},
+		{
+			name:   "boolean",
+			entry:  EventDataEntry{Type: "win:Boolean", Value: "true"},
+			expect: true,
+		},
+		{
+			name:   "binary lowercased",
+			entry:  EventDataEntry{Type: "win:Binary", Value: "A1B2"},
+			expect: "a1b2",
+		},
+		{
+			name:   "unrecognized type left as string",
+			entry:  EventDataEntry{Type: "win:SomeFutureType", Value: "raw"},
+			expect: "raw",
+		},
+		{
+			name:   "unparseable value falls back to raw string",
+			entry:  EventDataEntry{Type: "win:UInt32", Value: "not-a-number"},
+			expect: "not-a-number",
+		},
+		{
+			name:   "filetime ticks",
+			entry:  EventDataEntry{Type: "win:FILETIME", Value: "116444736000000000"},
+			expect: time.Unix(0, 0).UTC(),
+		},
+		{
+			name:  "filetime before the unix epoch falls back to raw string",
+			entry: EventDataEntry{Type: "win:FILETIME", Value: "1"},
+			// 1601-01-01 predates the unix epoch, so parseFILETIME refuses it
+			// and parseTypedValue falls back to the raw string.
+			expect: "1",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expect, tc.entry.parseTypedValue())
+		})
+	}
+}
+
+func TestParseEventData(t *testing.T) {
+	entries := []EventDataEntry{
+		{Name: "TargetUserName", Type: "win:UnicodeString", Value: "alice"},
+		{Name: "ProcessId", Type: "win:HexInt32", Value: "0x1a4"},
+		{Name: "", Type: "win:UnicodeString", Value: "ignored"},
+	}
+
+	untyped := parseEventData(entries, false)
+	require.Equal(t, "0x1a4", untyped["ProcessId"])
+
+	typed := parseEventData(entries, true)
+	require.Equal(t, int64(0x1a4), typed["ProcessId"])
+	require.Equal(t, "alice", typed["TargetUserName"])
+	require.NotContains(t, typed, "")
+}